@@ -1,11 +1,16 @@
 package simpletest
 
 import (
+	stderrors "errors"
 	"fmt"
+	"math/rand"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 )
 
@@ -33,7 +38,7 @@ but got error
   %+v
 `
 
-// WrongErrorTemplate is the template for the error message in case a test threw an error different from the one expected.
+// WrongErrorTemplate is the template for the error message in case a test threw an error whose message didn't contain the expected Error substring.
 const WrongErrorTemplate = `Wrong error!
 receiver
   %v
@@ -45,7 +50,31 @@ but got error
   %+v
 `
 
-// ExpectedErrorNotThrownTemplate is the template for the error message in case a test didn't throw an expected error.
+// WrongErrorIsTemplate is the template for the error message in case a test threw an error that didn't satisfy errors.Is against the expected ErrorIs target.
+const WrongErrorIsTemplate = `Wrong error!
+receiver
+  %v
+with input
+  %v
+expected error to satisfy errors.Is against
+  %+v
+but got error
+  %+v
+`
+
+// WrongErrorAsTemplate is the template for the error message in case a test threw an error that couldn't be unwrapped via errors.As into the expected ErrorAs target type.
+const WrongErrorAsTemplate = `Wrong error!
+receiver
+  %v
+with input
+  %v
+expected error to satisfy errors.As into
+  %v
+but got error
+  %+v
+`
+
+// ExpectedErrorNotThrownTemplate is the template for the error message in case a test didn't throw an error containing the expected Error substring.
 const ExpectedErrorNotThrownTemplate = `Expected error not thrown!
 receiver
   %v
@@ -57,43 +86,379 @@ but got nil error, and value
   %v
 `
 
+// ExpectedErrorIsNotThrownTemplate is the template for the error message in case a test didn't throw an error satisfying errors.Is against the expected ErrorIs target.
+const ExpectedErrorIsNotThrownTemplate = `Expected error not thrown!
+receiver
+  %v
+with input
+  %v
+expected error to satisfy errors.Is against
+  %+v
+but got nil error, and value
+  %v
+`
+
+// ExpectedErrorAsNotThrownTemplate is the template for the error message in case a test didn't throw an error satisfying errors.As into the expected ErrorAs target type.
+const ExpectedErrorAsNotThrownTemplate = `Expected error not thrown!
+receiver
+  %v
+with input
+  %v
+expected error to satisfy errors.As into
+  %v
+but got nil error, and value
+  %v
+`
+
 type (
 	// Case is a generic test case intended to simplify testing of simple value objects.
 	Case struct {
-		Receiver interface{} // The receiver (can be undefined if not testing a receiver method).
-		Input    interface{} // The input to the function under test.
-		Want     interface{} // The desired output of the function under test.
-		Error    string      // If an error is expected, then the error message should contain this substring.
+		Receiver    interface{}  // The receiver (can be undefined if not testing a receiver method).
+		Input       interface{}  // The input to the function under test.
+		Want        interface{}  // The desired output of the function under test.
+		Error       string       // If an error is expected, then the error message should contain this substring.
+		ErrorIs     error        // If set, the thrown error is also accepted if errors.Is matches this target (a match on ErrorIs, ErrorAs, or Error suffices).
+		ErrorAs     interface{}  // If set, the thrown error is also accepted if errors.As into this target (a pointer) succeeds.
+		Comparators []Comparator // If set, used instead of DefaultComparators to decide whether Want matches the result.
+		Comment     fmt.Stringer // If set, included in failure output; see Commentf.
+		Formatter   Formatter    // If set, used instead of DefaultFormatter to render failure output.
 	}
 
 	// Cases is a mapping from test names to test cases.
 	Cases map[string]Case
+
+	// Func is the function under test: given a Case, it produces the actual output and/or error.
+	Func func(tc *Case) (interface{}, error)
+
+	// NamedCase pairs a Case with the name of the subtest it runs under, preserving order.
+	NamedCase struct {
+		Name string
+		Case
+	}
+
+	// CaseList is an ordered alternative to Cases, run via RunList. Unlike Cases, its order
+	// is preserved as declared, so cases can be sequenced, focused, or skipped predictably.
+	CaseList []NamedCase
+
+	// RunOptions controls how RunListWithOptions executes a CaseList.
+	RunOptions struct {
+		Parallel bool                   // If true, t.Parallel() is called inside each subtest.
+		Focus    func(name string) bool // If set, only cases for which Focus returns true are run.
+		Skip     func(name string) bool // If set, cases for which Skip returns true are not run.
+		// StopOnFirstFailure, if true, stops running further cases after the first failure.
+		// It has no effect when Parallel is true: parallel subtests are deferred until the
+		// parent test function returns, so a failure isn't known until every case has already
+		// been queued.
+		StopOnFirstFailure bool
+	}
+
+	// Result carries the outcome of a Comparator evaluation.
+	Result struct {
+		Success bool
+		Message string // On failure, an optional description (e.g. a diff) to include in the failure output.
+	}
+
+	// Comparator decides whether got matches want, reporting the outcome as a Result.
+	Comparator func(want, got interface{}) Result
+
+	// FailureKind identifies which of the package's failure scenarios a FailureInfo describes.
+	FailureKind int
+
+	// FailureInfo carries everything a Formatter needs to describe a test failure.
+	FailureInfo struct {
+		Kind     FailureKind
+		Receiver interface{}
+		Input    interface{}
+		Want     interface{} // Case.Want (KindMismatch, KindUnexpectedError), or a description of the expected error otherwise.
+		Got      interface{} // The actual value (the *NotThrown kinds), the actual value or a Comparator's failure Message (KindMismatch), or the thrown error otherwise.
+		Comment  string
+	}
+
+	// Formatter builds the message included in a test failure. Install a custom Formatter via
+	// Case.Formatter to emit, e.g., colorized diffs, JSON events, or TAP lines.
+	Formatter interface {
+		Format(info FailureInfo) string
+	}
 )
 
-// Run executes input Cases in a random order (see RunSingle).
+const (
+	// KindMismatch indicates the actual value did not match Case.Want.
+	KindMismatch FailureKind = iota
+	// KindUnexpectedError indicates the test threw an error when none was expected.
+	KindUnexpectedError
+	// KindWrongError indicates the test threw an error whose message did not contain Case.Error.
+	KindWrongError
+	// KindWrongErrorIs indicates the test threw an error that did not satisfy errors.Is against Case.ErrorIs.
+	KindWrongErrorIs
+	// KindWrongErrorAs indicates the test threw an error that could not be unwrapped via errors.As into Case.ErrorAs.
+	KindWrongErrorAs
+	// KindErrorNotThrown indicates the test did not throw an error containing Case.Error.
+	KindErrorNotThrown
+	// KindErrorIsNotThrown indicates the test did not throw an error satisfying errors.Is against Case.ErrorIs.
+	KindErrorIsNotThrown
+	// KindErrorAsNotThrown indicates the test did not throw an error satisfying errors.As into Case.ErrorAs.
+	KindErrorAsNotThrown
+)
+
+// DefaultFormatter is the Formatter used when Case.Formatter is unset. It reproduces the
+// package's historical *Template-based output, appending the Case's Comment, if present.
+var DefaultFormatter Formatter = defaultFormatter{}
+
+type defaultFormatter struct{}
+
+func (defaultFormatter) Format(info FailureInfo) string {
+	var message string
+
+	switch info.Kind {
+	case KindMismatch:
+		message = fmt.Sprintf(FailedTemplate, info.Receiver, info.Input, info.Want, info.Got)
+	case KindUnexpectedError:
+		message = fmt.Sprintf(UnexpectedErrorTemplate, info.Receiver, info.Input, info.Want, info.Got)
+	case KindWrongError:
+		message = fmt.Sprintf(WrongErrorTemplate, info.Receiver, info.Input, info.Want, info.Got)
+	case KindWrongErrorIs:
+		message = fmt.Sprintf(WrongErrorIsTemplate, info.Receiver, info.Input, info.Want, info.Got)
+	case KindWrongErrorAs:
+		message = fmt.Sprintf(WrongErrorAsTemplate, info.Receiver, info.Input, info.Want, info.Got)
+	case KindErrorNotThrown:
+		message = fmt.Sprintf(ExpectedErrorNotThrownTemplate, info.Receiver, info.Input, info.Want, info.Got)
+	case KindErrorIsNotThrown:
+		message = fmt.Sprintf(ExpectedErrorIsNotThrownTemplate, info.Receiver, info.Input, info.Want, info.Got)
+	case KindErrorAsNotThrown:
+		message = fmt.Sprintf(ExpectedErrorAsNotThrownTemplate, info.Receiver, info.Input, info.Want, info.Got)
+	}
+
+	if info.Comment != "" {
+		message += fmt.Sprintf("comment\n  %s\n", info.Comment)
+	}
+
+	return message
+}
+
+// commentf is a deferred fmt.Stringer: its formatting cost is only paid when String is
+// called, i.e. when a Case it annotates actually fails.
+type commentf struct {
+	format string
+	args   []interface{}
+}
+
+func (c commentf) String() string {
+	return fmt.Sprintf(c.format, c.args...)
+}
+
+// Commentf returns an fmt.Stringer suitable for Case.Comment, formatted lazily with
+// fmt.Sprintf so that passing cases never pay the formatting cost. Use it to annotate why a
+// case exists, e.g. Commentf("regression for #123: apparently %v != %v", got, want).
+func Commentf(format string, args ...interface{}) fmt.Stringer {
+	return commentf{format: format, args: args}
+}
+
+// formatFailure renders info using the Case's Formatter (or DefaultFormatter, if unset),
+// attaches the Case's Comment, and wraps the result in an error.
+func formatFailure(testCase Case, info FailureInfo) error {
+	formatter := testCase.Formatter
+	if formatter == nil {
+		formatter = DefaultFormatter
+	}
+
+	if testCase.Comment != nil {
+		info.Comment = testCase.Comment.String()
+	}
+
+	return errors.New(formatter.Format(info))
+}
+
+// DefaultComparators is the equality chain used when Case.Comparators is unset.
+// It reproduces the package's historical behavior: nil-agnostic equality, zero-length slice
+// equivalence, and finally reflect.DeepEqual.
+var DefaultComparators = []Comparator{NilComparator, EmptySliceComparator, DeepEqualComparator}
+
+// NilComparator succeeds when both values are nil, regardless of their interface type.
+func NilComparator(want, got interface{}) Result {
+	return Result{Success: areBothNil(want, got)}
+}
+
+// EmptySliceComparator succeeds when both values are slices and both have length zero.
+// This is a workaround for reflect.DeepEqual treating empty slices and nil slices as different.
+func EmptySliceComparator(want, got interface{}) Result {
+	return Result{Success: areEqualZeroLengthSlices(want, got)}
+}
+
+// DeepEqualComparator succeeds when reflect.DeepEqual considers want and got equal.
+func DeepEqualComparator(want, got interface{}) Result {
+	return Result{Success: reflect.DeepEqual(want, got)}
+}
+
+// DeepEqualCmp returns a Comparator backed by github.com/google/go-cmp/cmp, accepting the
+// given opts (e.g. cmpopts.EquateEmpty(), cmpopts.EquateApproxTime(), custom cmp.Comparers).
+// On failure, the Result's Message holds a unified diff produced by cmp.Diff.
+func DeepEqualCmp(opts ...cmp.Option) Comparator {
+	return func(want, got interface{}) Result {
+		if cmp.Equal(want, got, opts...) {
+			return Result{Success: true}
+		}
+
+		return Result{Message: cmp.Diff(want, got, opts...)}
+	}
+}
+
+// Run executes input Cases ordered by name, for determinism (see RunSingle).
 // It returns false if any one test case failed, true otherwise.
-func Run(t *testing.T, testCases Cases, f func(tc *Case) (interface{}, error)) bool {
-	success := true
+// To control ordering, parallelism, focus/skip, or stop-on-first-failure behavior directly,
+// declare a CaseList and use RunList instead.
+func Run(t *testing.T, testCases Cases, f Func) bool {
+	return RunList(t, testCases.sorted(), f)
+}
 
-	for name, testCase := range testCases {
-		success = success && RunSingle(t, name, testCase, f)
+// sorted converts Cases into a CaseList ordered by name.
+func (testCases Cases) sorted() CaseList {
+	names := make([]string, 0, len(testCases))
+	for name := range testCases {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	return success
+	list := make(CaseList, 0, len(testCases))
+	for _, name := range names {
+		list = append(list, NamedCase{Name: name, Case: testCases[name]})
+	}
+
+	return list
 }
 
 // RunSingle takes a Case as input, as well as a function that should define how a result is to be obtained
 // from the test case. It returns whether the test succeeded or not.
-func RunSingle(t *testing.T, name string, testCase Case, f func(tc *Case) (interface{}, error)) bool {
+func RunSingle(t *testing.T, name string, testCase Case, f Func) bool {
+	return RunSingleWithOptions(t, name, testCase, RunOptions{}, f)
+}
+
+// RunSingleWithOptions behaves like RunSingle, additionally honoring opts.Parallel. Note that
+// when opts.Parallel is true, the subtest is deferred until the parent test function returns,
+// so the returned bool (like t.Run's) does not yet reflect whether the case actually passed.
+func RunSingleWithOptions(t *testing.T, name string, testCase Case, opts RunOptions, f Func) bool {
 	return t.Run(name, func(t *testing.T) {
+		if opts.Parallel {
+			t.Parallel()
+		}
+
 		if err := execute(testCase, f); err != nil {
 			t.Errorf("%v", err)
 		}
 	})
 }
 
+// RunList executes a CaseList in order (see RunSingle).
+// It returns false if any one test case failed, true otherwise.
+func RunList(t *testing.T, testCases CaseList, f Func) bool {
+	return RunListWithOptions(t, testCases, RunOptions{}, f)
+}
+
+// RunListWithOptions executes a CaseList in order, honoring opts.
+// It returns false if any one test case failed, true otherwise. If opts.Parallel is true, this
+// return value (and opts.StopOnFirstFailure) can't account for parallel subtests, since their
+// outcome isn't known until the parent test function returns; check t.Failed() on t after your
+// test function returns if you need the real outcome in that case.
+func RunListWithOptions(t *testing.T, testCases CaseList, opts RunOptions, f Func) bool {
+	success := true
+
+	for _, namedCase := range testCases {
+		if opts.StopOnFirstFailure && !opts.Parallel && !success {
+			break
+		}
+
+		if opts.Focus != nil && !opts.Focus(namedCase.Name) {
+			continue
+		}
+
+		if opts.Skip != nil && opts.Skip(namedCase.Name) {
+			continue
+		}
+
+		ok := RunSingleWithOptions(t, namedCase.Name, namedCase.Case, opts, f)
+		success = success && ok
+	}
+
+	return success
+}
+
+// RunGenerated generates n Cases with gen, one per call, feeding each through the same
+// execute pipeline as a static Case, so panics and the standard failure templates behave
+// identically. gen is given a *rand.Rand seeded from the current time, and the seed is
+// logged so a failure can be reproduced; on failure, the offending Case is also logged via
+// caseLiteral so it can be pasted back into a static Cases map as a regression test.
+// It returns false if any one generated case failed, true otherwise.
+func RunGenerated(t *testing.T, name string, gen func(r *rand.Rand) Case, n int, f Func) bool {
+	return t.Run(name, func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		r := rand.New(rand.NewSource(seed))
+
+		for i := 0; i < n; i++ {
+			testCase := gen(r)
+
+			if err := execute(testCase, f); err != nil {
+				t.Errorf("%v\nseed\n  %d\ncase\n  %s", err, seed, caseLiteral(testCase))
+			}
+		}
+	})
+}
+
+// RunFuzz adapts simpletest to Go's native fuzzing (testing.F): each Case in seed seeds the
+// fuzz corpus via its Input (which must be a string), mutate turns each fuzz-generated string
+// back into a full Case, and the result is fed through the same execute pipeline as a static
+// Case, so panics and the standard failure templates behave identically. On failure, the
+// offending Case is logged via caseLiteral so it can be pasted back into a static Cases map
+// as a regression test.
+func RunFuzz(f *testing.F, seed Cases, mutate func(Case) Case, target Func) {
+	for _, namedCase := range seed.sorted() {
+		if input, ok := namedCase.Input.(string); ok {
+			f.Add(input)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		testCase := mutate(Case{Input: input})
+
+		if err := execute(testCase, target); err != nil {
+			t.Errorf("%v\ncase\n  %s", err, caseLiteral(testCase))
+		}
+	})
+}
+
+// caseLiteral renders the reproducible fields of testCase (Receiver, Input, Want, Error,
+// ErrorIs, ErrorAs) as a Go composite literal in the unqualified style this package's own
+// tests use (e.g. Case{Input: "x", Want: "y"}), so it can be pasted directly into an internal
+// `package simpletest` test file without needing a self-import. Zero-valued fields are
+// omitted. Comparators, Comment, and Formatter are never reproducible as literals, so they're
+// left out even when set. Each value is printed with %#v, so only values whose %#v form is
+// valid Go (strings, numbers, structs of exported types) paste back as-is; an error value or a
+// pointer, as ErrorIs and ErrorAs usually hold, prints as its %#v form, which does not compile.
+func caseLiteral(testCase Case) string {
+	var fields []string
+
+	if testCase.Receiver != nil {
+		fields = append(fields, fmt.Sprintf("Receiver: %#v", testCase.Receiver))
+	}
+	if testCase.Input != nil {
+		fields = append(fields, fmt.Sprintf("Input: %#v", testCase.Input))
+	}
+	if testCase.Want != nil {
+		fields = append(fields, fmt.Sprintf("Want: %#v", testCase.Want))
+	}
+	if len(testCase.Error) > 0 {
+		fields = append(fields, fmt.Sprintf("Error: %#v", testCase.Error))
+	}
+	if testCase.ErrorIs != nil {
+		fields = append(fields, fmt.Sprintf("ErrorIs: %#v", testCase.ErrorIs))
+	}
+	if testCase.ErrorAs != nil {
+		fields = append(fields, fmt.Sprintf("ErrorAs: %#v", testCase.ErrorAs))
+	}
+
+	return fmt.Sprintf("Case{%s}", strings.Join(fields, ", "))
+}
+
 // execute actually executes each individual test and handles the result.
-func execute(testCase Case, f func(*Case) (interface{}, error)) (err error) {
+func execute(testCase Case, f Func) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			if e, ok := r.(error); ok {
@@ -109,29 +474,66 @@ func execute(testCase Case, f func(*Case) (interface{}, error)) (err error) {
 		return handleError(testCase, err)
 	}
 
-	if len(testCase.Error) > 0 {
-		return errors.Errorf(ExpectedErrorNotThrownTemplate, testCase.Receiver, testCase.Input, testCase.Error, got)
+	if expected := expectedErrorDescription(testCase); expected != nil {
+		info := FailureInfo{Kind: expectedErrorKind(testCase), Receiver: testCase.Receiver, Input: testCase.Input, Want: expected, Got: got}
+		return formatFailure(testCase, info)
 	}
 
 	return compare(testCase, got)
 }
 
-// compare compares the wanted output defined in the Case with the actual output,
-// returning an error if there is no match.
-func compare(testCase Case, got interface{}) error {
-	if areBothNil(testCase.Want, got) {
+// expectedErrorDescription returns a human-readable description of the error a Case expects
+// (from ErrorIs, ErrorAs, or Error, in that order of precedence — the same order handleError
+// uses to report which criterion was violated), or nil if it expects none.
+func expectedErrorDescription(testCase Case) interface{} {
+	switch {
+	case testCase.ErrorIs != nil:
+		return testCase.ErrorIs
+	case testCase.ErrorAs != nil:
+		return reflect.TypeOf(testCase.ErrorAs).Elem()
+	case len(testCase.Error) > 0:
+		return testCase.Error
+	default:
 		return nil
 	}
+}
 
-	if areEqualZeroLengthSlices(testCase.Want, got) {
-		return nil
+// expectedErrorKind reports the specific not-thrown FailureKind to use for a Case, matching
+// the same ErrorIs/ErrorAs/Error precedence expectedErrorDescription uses.
+func expectedErrorKind(testCase Case) FailureKind {
+	switch {
+	case testCase.ErrorIs != nil:
+		return KindErrorIsNotThrown
+	case testCase.ErrorAs != nil:
+		return KindErrorAsNotThrown
+	default:
+		return KindErrorNotThrown
 	}
+}
 
-	if reflect.DeepEqual(testCase.Want, got) {
-		return nil
+// compare compares the wanted output defined in the Case with the actual output,
+// running it through each of the Case's Comparators (or DefaultComparators, if unset)
+// until one succeeds, and returning an error if none do.
+func compare(testCase Case, got interface{}) error {
+	comparators := testCase.Comparators
+	if comparators == nil {
+		comparators = DefaultComparators
 	}
 
-	return errors.Errorf(FailedTemplate, testCase.Receiver, testCase.Input, testCase.Want, got)
+	var message interface{} = got
+	for _, comparator := range comparators {
+		result := comparator(testCase.Want, got)
+		if result.Success {
+			return nil
+		}
+
+		if result.Message != "" {
+			message = result.Message
+		}
+	}
+
+	info := FailureInfo{Kind: KindMismatch, Receiver: testCase.Receiver, Input: testCase.Input, Want: testCase.Want, Got: message}
+	return formatFailure(testCase, info)
 }
 
 // areBothNil checks whether two values are nil, regardless of their interface type.
@@ -163,18 +565,40 @@ func areEqualZeroLengthSlices(a interface{}, b interface{}) bool {
 	return false
 }
 
-// handleError will check if the error thrown by a test was expected,
-// and whether the error message contains the expected Error phrase defined in the Case.
+// handleError will check if the error thrown by a test was expected, matching it against
+// Case.ErrorIs (via errors.Is), Case.ErrorAs (via errors.As), or the Case.Error substring,
+// in that order of precedence.
 func handleError(testCase Case, err error) error {
-	if len(testCase.Error) == 0 {
-		return errors.Errorf(UnexpectedErrorTemplate, testCase.Receiver, testCase.Input, testCase.Want, err)
+	if testCase.ErrorIs == nil && testCase.ErrorAs == nil && len(testCase.Error) == 0 {
+		info := FailureInfo{Kind: KindUnexpectedError, Receiver: testCase.Receiver, Input: testCase.Input, Want: testCase.Want, Got: err}
+		return formatFailure(testCase, info)
+	}
+
+	if testCase.ErrorIs != nil && stderrors.Is(err, testCase.ErrorIs) {
+		return nil
 	}
 
-	if strings.Contains(strings.ToLower(err.Error()), strings.ToLower(testCase.Error)) {
+	if testCase.ErrorAs != nil && stderrors.As(err, testCase.ErrorAs) {
 		return nil
 	}
 
-	return errors.Errorf(WrongErrorTemplate, testCase.Receiver, testCase.Input, testCase.Error, err)
+	if len(testCase.Error) > 0 && strings.Contains(strings.ToLower(err.Error()), strings.ToLower(testCase.Error)) {
+		return nil
+	}
+
+	// None of the criteria the Case set matched; report against the highest-precedence one
+	// (the same order expectedErrorDescription uses), for consistency across both functions.
+	switch {
+	case testCase.ErrorIs != nil:
+		info := FailureInfo{Kind: KindWrongErrorIs, Receiver: testCase.Receiver, Input: testCase.Input, Want: testCase.ErrorIs, Got: err}
+		return formatFailure(testCase, info)
+	case testCase.ErrorAs != nil:
+		info := FailureInfo{Kind: KindWrongErrorAs, Receiver: testCase.Receiver, Input: testCase.Input, Want: reflect.TypeOf(testCase.ErrorAs).Elem(), Got: err}
+		return formatFailure(testCase, info)
+	default:
+		info := FailureInfo{Kind: KindWrongError, Receiver: testCase.Receiver, Input: testCase.Input, Want: testCase.Error, Got: err}
+		return formatFailure(testCase, info)
+	}
 }
 
 func toError(recovered interface{}) error {
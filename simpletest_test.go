@@ -3,7 +3,24 @@ package simpletest
 import (
 	"errors"
 	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
 	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// customError is used to exercise ErrorAs, which requires a concrete error type to unwrap into.
+type customError struct {
+	Code int
+}
+
+func (e *customError) Error() string { return fmt.Sprintf("custom error %d", e.Code) }
+
+var (
+	errSentinel  = errors.New("sentinel")
+	errSentinel2 = errors.New("sentinel2")
 )
 
 var (
@@ -30,6 +47,18 @@ var (
 	panicErrorFunc = func(c *Case) (output interface{}, err error) {
 		panic(fmt.Errorf("panicked"))
 	}
+
+	errorIsFunc = func(c *Case) (output interface{}, err error) {
+		return nil, fmt.Errorf("wrapped: %w", errSentinel)
+	}
+
+	errorAsFunc = func(c *Case) (output interface{}, err error) {
+		return nil, fmt.Errorf("wrapped: %w", &customError{Code: 42})
+	}
+
+	fooSubstringFunc = func(c *Case) (output interface{}, err error) {
+		return nil, errors.New("foo wrapped differently")
+	}
 )
 
 func TestRun(t *testing.T) {
@@ -41,6 +70,90 @@ func TestRun(t *testing.T) {
 	Run(t, successCases, inputOrErrFunc)
 }
 
+func TestRunList(t *testing.T) {
+	successCases := CaseList{
+		{Name: "Output matches what we wanted", Case: Case{Input: "A", Want: "A"}},
+		{Name: "Expected error", Case: Case{Input: "A", Error: "foo"}},
+	}
+
+	RunList(t, successCases, inputOrErrFunc)
+}
+
+func TestRunGenerated(t *testing.T) {
+	gen := func(r *rand.Rand) Case {
+		s := string(rune('a' + r.Intn(26)))
+		return Case{Input: s, Want: s}
+	}
+
+	if !RunGenerated(t, "Output matches what we generated", gen, 10, inputOrErrFunc) {
+		t.Error("Expected RunGenerated to succeed")
+	}
+}
+
+// RunFuzz itself requires a *testing.F supplied by `go test -fuzz`, so it's exercised here
+// via the same mutate-then-execute path it wraps internally.
+func Test_RunFuzz_mutate(t *testing.T) {
+	mutate := func(c Case) Case {
+		c.Want = c.Input
+		return c
+	}
+
+	testCase := mutate(Case{Input: "A"})
+	if err := execute(testCase, inputOrErrFunc); err != nil {
+		t.Errorf("%v", err)
+	}
+}
+
+func TestRunListWithOptions(t *testing.T) {
+	successCases := CaseList{
+		{Name: "Output matches what we wanted", Case: Case{Input: "A", Want: "A"}},
+		{Name: "Skipped", Case: Case{Input: "A", Want: "B"}},
+	}
+
+	opts := RunOptions{
+		Parallel: true,
+		Skip:     func(name string) bool { return name == "Skipped" },
+	}
+
+	if !RunListWithOptions(t, successCases, opts, inputOrErrFunc) {
+		t.Error("Expected RunListWithOptions to succeed with the failing case skipped")
+	}
+}
+
+func TestRunListWithOptions_StopOnFirstFailureIgnoredWhenParallel(t *testing.T) {
+	var mu sync.Mutex
+	var ran []string
+
+	trackingFunc := func(c *Case) (output interface{}, err error) {
+		mu.Lock()
+		ran = append(ran, fmt.Sprint(c.Input))
+		mu.Unlock()
+
+		return c.Input, err
+	}
+
+	// Both cases succeed: since Parallel subtests are deferred until this test function
+	// returns, success is never observably false at loop time, so StopOnFirstFailure cannot
+	// stop the loop early regardless of outcome. This pins that both cases still get queued.
+	cases := CaseList{
+		{Name: "First", Case: Case{Input: "A", Want: "A"}},
+		{Name: "Second", Case: Case{Input: "B", Want: "B"}},
+	}
+
+	opts := RunOptions{Parallel: true, StopOnFirstFailure: true}
+
+	RunListWithOptions(t, cases, opts, trackingFunc)
+
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(ran) != len(cases) {
+			t.Errorf("Expected StopOnFirstFailure to have no effect under Parallel, ran %v", ran)
+		}
+	})
+}
+
 func Test_areBothNil(t *testing.T) {
 	var nilVar *interface{}
 
@@ -62,6 +175,109 @@ func Test_areEqualZeroLengthSlices(t *testing.T) {
 	Run(t, successCases, inputOrErrFunc)
 }
 
+func Test_DeepEqualCmp(t *testing.T) {
+	successCases := Cases{
+		"Empty slice == nil slice": {
+			Input:       []string{},
+			Want:        []string(nil),
+			Comparators: []Comparator{DeepEqualCmp(cmpopts.EquateEmpty())},
+		},
+	}
+
+	Run(t, successCases, inputOrErrFunc)
+
+	failureCase := Case{
+		Input:       "A",
+		Want:        "B",
+		Comparators: []Comparator{DeepEqualCmp()},
+	}
+	result := DeepEqualCmp()(failureCase.Want, failureCase.Input)
+	expectedError := fmt.Sprintf(FailedTemplate, nil, failureCase.Input, failureCase.Want, result.Message)
+	t.Run("Diff included on mismatch", doTestExecuteError(failureCase, inputOrErrFunc, expectedError))
+}
+
+func Test_handleError_ErrorIs(t *testing.T) {
+	successCases := Cases{
+		"Wrapped error matches ErrorIs target": {ErrorIs: errSentinel},
+	}
+
+	Run(t, successCases, errorIsFunc)
+}
+
+func Test_handleError_ErrorAs(t *testing.T) {
+	var target *customError
+
+	testCase := Case{ErrorAs: &target}
+	if err := execute(testCase, errorAsFunc); err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+
+	if target == nil || target.Code != 42 {
+		t.Errorf("Expected ErrorAs to extract a *customError with Code 42, got %+v", target)
+	}
+}
+
+func Test_handleError_WrongErrorIsTemplate(t *testing.T) {
+	failureCase := Case{ErrorIs: errSentinel2}
+	expectedError := fmt.Sprintf(WrongErrorIsTemplate, nil, failureCase.Input, failureCase.ErrorIs, "wrapped: sentinel")
+	t.Run("ErrorIs mismatch uses errors.Is wording", doTestExecuteError(failureCase, errorIsFunc, expectedError))
+}
+
+func Test_handleError_WrongErrorAsTemplate(t *testing.T) {
+	var target *customError
+
+	mismatchFunc := func(c *Case) (output interface{}, err error) {
+		return nil, errors.New("no custom error here")
+	}
+
+	failureCase := Case{ErrorAs: &target}
+	expectedError := fmt.Sprintf(WrongErrorAsTemplate, nil, failureCase.Input, reflect.TypeOf(failureCase.ErrorAs).Elem(), "no custom error here")
+	t.Run("ErrorAs mismatch uses errors.As wording", doTestExecuteError(failureCase, mismatchFunc, expectedError))
+}
+
+func Test_caseLiteral(t *testing.T) {
+	got := caseLiteral(Case{Input: "x", Want: "y"})
+	want := `Case{Input: "x", Want: "y"}`
+	if got != want {
+		t.Errorf("caseLiteral() = %s, want %s", got, want)
+	}
+}
+
+func Test_handleError_FallsBackToErrorWhenErrorIsDoesNotMatch(t *testing.T) {
+	successCases := Cases{
+		"Error substring matches even though ErrorIs target does not": {Error: "foo", ErrorIs: errSentinel2},
+	}
+
+	Run(t, successCases, fooSubstringFunc)
+}
+
+func Test_Commentf(t *testing.T) {
+	failureCase := Case{
+		Input:   "A",
+		Want:    "B",
+		Comment: Commentf("regression for #123: apparently %v != %v", "A", "B"),
+	}
+	expectedError := fmt.Sprintf(FailedTemplate, nil, failureCase.Input, failureCase.Want, failureCase.Input) +
+		"comment\n  regression for #123: apparently A != B\n"
+	t.Run("Comment included on mismatch", doTestExecuteError(failureCase, inputOrErrFunc, expectedError))
+}
+
+type tapFormatter struct{}
+
+func (tapFormatter) Format(info FailureInfo) string {
+	return fmt.Sprintf("not ok - want %v, got %v", info.Want, info.Got)
+}
+
+func Test_Formatter(t *testing.T) {
+	failureCase := Case{
+		Input:     "A",
+		Want:      "B",
+		Formatter: tapFormatter{},
+	}
+	expectedError := "not ok - want B, got A"
+	t.Run("Custom formatter replaces default template", doTestExecuteError(failureCase, inputOrErrFunc, expectedError))
+}
+
 func Test_execute(t *testing.T) {
 	failureCase := Case{Input: "A", Want: "B"}
 	expectedError := fmt.Sprintf(FailedTemplate, nil, failureCase.Input, failureCase.Want, failureCase.Input)